@@ -0,0 +1,214 @@
+package mgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RunCommand in database and decode reply into result. Nil result discards reply
+func (db *DB) RunCommand(command interface{}, result interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.RunCommandCtx(ctx, command, result)
+}
+
+// RunCommandCtx in database with context and decode reply into result. Nil result discards reply
+func (db *DB) RunCommandCtx(ctx context.Context, command interface{}, result interface{}) error {
+	return decodeReply(db.Database(db.name).RunCommand(ctx, command), result)
+}
+
+// AdminCommand runs admin only command in admin database and decode reply into result. Nil result discards reply
+func (db *DB) AdminCommand(command interface{}, result interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.AdminCommandCtx(ctx, command, result)
+}
+
+// AdminCommandCtx runs admin only command in admin database with context and decode reply into result.
+// Nil result discards reply
+func (db *DB) AdminCommandCtx(ctx context.Context, command interface{}, result interface{}) error {
+	return decodeReply(db.Database("admin").RunCommand(ctx, command), result)
+}
+
+func decodeReply(res *mongo.SingleResult, result interface{}) error {
+	if result == nil {
+		return res.Err()
+	}
+	return res.Decode(result)
+}
+
+// ServerInfo is part of buildInfo command result
+type ServerInfo struct {
+	Version      string  `bson:"version"`
+	VersionArray []int32 `bson:"versionArray"`
+	GitVersion   string  `bson:"gitVersion"`
+}
+
+// AtLeast reports whether server version is major.minor or newer
+func (s ServerInfo) AtLeast(major, minor int32) bool {
+	if len(s.VersionArray) < 2 {
+		return false
+	}
+	if s.VersionArray[0] != major {
+		return s.VersionArray[0] > major
+	}
+	return s.VersionArray[1] >= minor
+}
+
+// ServerInfo runs buildInfo command
+func (db *DB) ServerInfo() (ServerInfo, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.ServerInfoCtx(ctx)
+}
+
+// ServerInfoCtx runs buildInfo command with context
+func (db *DB) ServerInfoCtx(ctx context.Context) (ServerInfo, error) {
+	var info ServerInfo
+	err := db.AdminCommandCtx(ctx, bson.D{{Key: "buildInfo", Value: 1}}, &info)
+	return info, err
+}
+
+// ServerVersion of mongo, e.g. "6.0.5"
+func (db *DB) ServerVersion() (string, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.ServerVersionCtx(ctx)
+}
+
+// ServerVersionCtx of mongo with context, e.g. "6.0.5"
+func (db *DB) ServerVersionCtx(ctx context.Context) (string, error) {
+	info, err := db.ServerInfoCtx(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.Version, nil
+}
+
+// ListDatabaseNames on server. Nil filter lists all databases
+func (db *DB) ListDatabaseNames(filter interface{}) ([]string, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.ListDatabaseNamesCtx(ctx, filter)
+}
+
+// ListDatabaseNamesCtx on server with context. Nil filter lists all databases
+func (db *DB) ListDatabaseNamesCtx(ctx context.Context, filter interface{}) ([]string, error) {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	return db.Client.ListDatabaseNames(ctx, filter)
+}
+
+// CollectionStats runs collStats command for collection, e.g. size, count and avgObjSize
+func (db *DB) CollectionStats(collection string) (bson.M, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.CollectionStatsCtx(ctx, collection)
+}
+
+// CollectionStatsCtx runs collStats command for collection with context, e.g. size, count and avgObjSize
+func (db *DB) CollectionStatsCtx(ctx context.Context, collection string) (bson.M, error) {
+	var stats bson.M
+	err := db.RunCommandCtx(ctx, bson.D{{Key: "collStats", Value: collection}}, &stats)
+	return stats, err
+}
+
+// DatabaseStats runs dbStats command, e.g. dataSize, indexSize and collections.
+// Sizes are divided by scale, 0 or 1 for bytes and 1024*1024 for megabytes
+func (db *DB) DatabaseStats(scale int32) (bson.M, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.DatabaseStatsCtx(ctx, scale)
+}
+
+// DatabaseStatsCtx runs dbStats command with context, e.g. dataSize, indexSize and collections.
+// Sizes are divided by scale, 0 or 1 for bytes and 1024*1024 for megabytes
+func (db *DB) DatabaseStatsCtx(ctx context.Context, scale int32) (bson.M, error) {
+	if scale <= 0 {
+		scale = 1
+	}
+
+	var stats bson.M
+	err := db.RunCommandCtx(ctx, bson.D{{Key: "dbStats", Value: 1}, {Key: "scale", Value: scale}}, &stats)
+	return stats, err
+}
+
+// Explain verbosity modes
+const (
+	ExplainQueryPlanner      = "queryPlanner"
+	ExplainExecutionStats    = "executionStats"
+	ExplainAllPlansExecution = "allPlansExecution"
+)
+
+// Explain plan of find on collection with filter and options. Sort, projection, skip,
+// limit, hint and collation of opts are used, the last set value wins
+func (db *DB) Explain(collection string, filter interface{}, verbosity string, opts ...*options.FindOptions) (bson.M, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.ExplainCtx(ctx, collection, filter, verbosity, opts...)
+}
+
+// ExplainCtx plan of find on collection with context, filter and options. Sort, projection, skip,
+// limit, hint and collation of opts are used, the last set value wins. Empty verbosity is queryPlanner
+func (db *DB) ExplainCtx(ctx context.Context, collection string, filter interface{}, verbosity string, opts ...*options.FindOptions) (bson.M, error) {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	if verbosity == "" {
+		verbosity = ExplainQueryPlanner
+	}
+
+	find := bson.D{{Key: "find", Value: collection}, {Key: "filter", Value: filter}}
+	var sort, projection, hint interface{}
+	var skip, limit *int64
+	var collation *options.Collation
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+		if o.Sort != nil {
+			sort = o.Sort
+		}
+		if o.Projection != nil {
+			projection = o.Projection
+		}
+		if o.Hint != nil {
+			hint = o.Hint
+		}
+		if o.Skip != nil {
+			skip = o.Skip
+		}
+		if o.Limit != nil {
+			limit = o.Limit
+		}
+		if o.Collation != nil {
+			collation = o.Collation
+		}
+	}
+	if sort != nil {
+		find = append(find, bson.E{Key: "sort", Value: sort})
+	}
+	if projection != nil {
+		find = append(find, bson.E{Key: "projection", Value: projection})
+	}
+	if hint != nil {
+		find = append(find, bson.E{Key: "hint", Value: hint})
+	}
+	if skip != nil {
+		find = append(find, bson.E{Key: "skip", Value: *skip})
+	}
+	if limit != nil {
+		find = append(find, bson.E{Key: "limit", Value: *limit})
+	}
+	if collation != nil {
+		find = append(find, bson.E{Key: "collation", Value: collation.ToDocument()})
+	}
+
+	var plan bson.M
+	err := db.RunCommandCtx(ctx, bson.D{{Key: "explain", Value: find}, {Key: "verbosity", Value: verbosity}}, &plan)
+	return plan, err
+}
@@ -0,0 +1,15 @@
+// Package mgo is a thin wrapper of mongo driver bound to one database.
+//
+// Every method has a Ctx variant taking context as the first argument, methods without it
+// use background context limited by SetDefaultTimeout. Pass the session context given by WithTransaction to Ctx methods
+// to run them inside the transaction:
+//
+//	err := db.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+//		if err := db.UpdateItemCtx(sessCtx, "accounts", from, withdraw); err != nil {
+//			return err
+//		}
+//		return db.UpdateItemCtx(sessCtx, "accounts", to, deposit)
+//	})
+//
+// Methods without Ctx, e.g. InsertItem, and GridFS uploads and downloads always run outside of transaction.
+package mgo
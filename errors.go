@@ -0,0 +1,82 @@
+package mgo
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// ErrNotFound is returned when no document matched the filter
+var ErrNotFound = errors.New("mgo: not found")
+
+// ErrVersionConflict is returned when document version differs from expected one
+var ErrVersionConflict = errors.New("mgo: version conflict")
+
+// ErrCollectionExists is returned when created collection already exists
+var ErrCollectionExists = errors.New("mgo: collection already exists")
+
+const namespaceExistsCode = 48
+
+// collectionExists translates NamespaceExists command error into ErrCollectionExists keeping the original error
+func collectionExists(err error) error {
+	var ce mongo.CommandError
+	if errors.As(err, &ce) && ce.Code == namespaceExistsCode {
+		return fmt.Errorf("%w: %w", ErrCollectionExists, err)
+	}
+	return err
+}
+
+// notFound translates mongo.ErrNoDocuments and gridfs.ErrFileNotFound into ErrNotFound keeping the original error
+func notFound(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, gridfs.ErrFileNotFound) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
+const duplicateKeyCode = 11000
+
+// IsDuplicateKey reports whether err is caused by unique index violation.
+// Single, bulk write and command errors are inspected
+func IsDuplicateKey(err error) bool {
+	var we mongo.WriteException
+	if errors.As(err, &we) {
+		for _, e := range we.WriteErrors {
+			if e.Code == duplicateKeyCode {
+				return true
+			}
+		}
+	}
+
+	var bwe mongo.BulkWriteException
+	if errors.As(err, &bwe) {
+		for _, e := range bwe.WriteErrors {
+			if e.Code == duplicateKeyCode {
+				return true
+			}
+		}
+	}
+
+	var ce mongo.CommandError
+	if errors.As(err, &ce) {
+		return ce.Code == duplicateKeyCode
+	}
+
+	return false
+}
+
+const (
+	indexOptionsConflictCode  = 85
+	indexKeySpecsConflictCode = 86
+)
+
+// IsIndexConflict reports whether err is caused by existing index with the same name or keys but other options
+func IsIndexConflict(err error) bool {
+	var ce mongo.CommandError
+	if errors.As(err, &ce) {
+		return ce.Code == indexOptionsConflictCode || ce.Code == indexKeySpecsConflictCode
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package mgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetAll typed items from collection. Empty result is not an error
+func GetAll[T any](db *DB, collection string, filter interface{}, opts ...*options.FindOptions) ([]T, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return GetAllCtx[T](ctx, db, collection, filter, opts...)
+}
+
+// GetAllCtx typed items from collection with context. Empty result is not an error
+func GetAllCtx[T any](ctx context.Context, db *DB, collection string, filter interface{}, opts ...*options.FindOptions) ([]T, error) {
+	var items []T
+	if err := db.GetItemsCtx(ctx, collection, filter, &items, opts...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetOne typed item from collection. Returns zero value and ErrNotFound if nothing matched
+func GetOne[T any](db *DB, collection string, filter interface{}, opts ...*options.FindOneOptions) (T, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return GetOneCtx[T](ctx, db, collection, filter, opts...)
+}
+
+// GetOneCtx typed item from collection with context. Returns zero value and ErrNotFound if nothing matched
+func GetOneCtx[T any](ctx context.Context, db *DB, collection string, filter interface{}, opts ...*options.FindOneOptions) (T, error) {
+	var item T
+	if err := db.GetItemCtx(ctx, collection, filter, &item, opts...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return item, nil
+}
+
+// AggregateInto decodes pipeline output on collection into typed items. Returns empty slice on no results
+func AggregateInto[T any](db *DB, collection string, pipeline interface{}, opts ...*options.AggregateOptions) ([]T, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return AggregateIntoCtx[T](ctx, db, collection, pipeline, opts...)
+}
+
+// AggregateIntoCtx decodes pipeline output on collection into typed items with context. Returns empty slice on no results
+func AggregateIntoCtx[T any](ctx context.Context, db *DB, collection string, pipeline interface{}, opts ...*options.AggregateOptions) ([]T, error) {
+	var items []T
+	if err := db.AggregateCtx(ctx, collection, pipeline, &items, opts...); err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return items, nil
+}
+
+// InsertMany typed items in collection
+func InsertMany[T any](db *DB, collection string, items []T) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return InsertManyCtx(ctx, db, collection, items)
+}
+
+// InsertManyCtx typed items in collection with context
+func InsertManyCtx[T any](ctx context.Context, db *DB, collection string, items []T) error {
+	return db.InsertItemsCtx(ctx, collection, toInterfaces(items))
+}
+
+// toInterfaces boxes typed items for driver calls
+func toInterfaces[T any](items []T) []interface{} {
+	boxed := make([]interface{}, len(items))
+	for i := range items {
+		boxed[i] = items[i]
+	}
+	return boxed
+}
@@ -0,0 +1,113 @@
+package mgo
+
+import (
+	"context"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// gridBucket of database by name, empty name is default "fs" bucket
+func (db *DB) gridBucket(bucket string) (*gridfs.Bucket, error) {
+	opts := options.GridFSBucket()
+	if bucket != "" {
+		opts.SetName(bucket)
+	}
+	return gridfs.NewBucket(db.Database(db.name), opts)
+}
+
+// UploadFile to GridFS bucket and return file id. Nil metadata is not stored.
+// Chunk size can be set with options.GridFSUpload().SetChunkSizeBytes
+func (db *DB) UploadFile(bucket, filename string, r io.Reader, metadata interface{}, opts ...*options.UploadOptions) (primitive.ObjectID, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.UploadFileCtx(ctx, bucket, filename, r, metadata, opts...)
+}
+
+// UploadFileCtx to GridFS bucket with context and return file id. Nil metadata is not stored.
+// Chunk size can be set with options.GridFSUpload().SetChunkSizeBytes. Only deadline of ctx is used
+func (db *DB) UploadFileCtx(ctx context.Context, bucket, filename string, r io.Reader, metadata interface{}, opts ...*options.UploadOptions) (primitive.ObjectID, error) {
+	b, err := db.gridBucket(bucket)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = b.SetWriteDeadline(deadline)
+	}
+
+	if metadata != nil {
+		opts = append([]*options.UploadOptions{options.GridFSUpload().SetMetadata(metadata)}, opts...)
+	}
+	return b.UploadFromStream(filename, r, opts...)
+}
+
+// DownloadFile from GridFS bucket by id into w. Returns ErrNotFound if file does not exist
+func (db *DB) DownloadFile(bucket string, id interface{}, w io.Writer) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.DownloadFileCtx(ctx, bucket, id, w)
+}
+
+// DownloadFileCtx from GridFS bucket by id into w with context. Returns ErrNotFound if file does not exist.
+// Only deadline of ctx is used
+func (db *DB) DownloadFileCtx(ctx context.Context, bucket string, id interface{}, w io.Writer) error {
+	b, err := db.gridBucket(bucket)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = b.SetReadDeadline(deadline)
+	}
+
+	_, err = b.DownloadToStream(id, w)
+	return notFound(err)
+}
+
+// DeleteFile with its chunks from GridFS bucket by id. Returns ErrNotFound if file does not exist
+func (db *DB) DeleteFile(bucket string, id interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.DeleteFileCtx(ctx, bucket, id)
+}
+
+// DeleteFileCtx with its chunks from GridFS bucket by id with context. Returns ErrNotFound if file does not exist
+func (db *DB) DeleteFileCtx(ctx context.Context, bucket string, id interface{}) error {
+	b, err := db.gridBucket(bucket)
+	if err != nil {
+		return err
+	}
+	return notFound(b.DeleteContext(ctx, id))
+}
+
+// ListFiles of GridFS bucket matching filter on files collection. Nil filter lists all files
+func (db *DB) ListFiles(bucket string, filter interface{}, opts ...*options.GridFSFindOptions) ([]gridfs.File, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.ListFilesCtx(ctx, bucket, filter, opts...)
+}
+
+// ListFilesCtx of GridFS bucket matching filter on files collection with context. Nil filter lists all files
+func (db *DB) ListFilesCtx(ctx context.Context, bucket string, filter interface{}, opts ...*options.GridFSFindOptions) ([]gridfs.File, error) {
+	b, err := db.gridBucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	cur, err := b.FindContext(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var files []gridfs.File
+	if err := cur.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,206 @@
+package mgo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// indexSpec is part of listIndexes result compared by EnsureIndexes
+type indexSpec struct {
+	Name                    string   `bson:"name"`
+	Key                     bson.D   `bson:"key"`
+	Unique                  bool     `bson:"unique"`
+	Sparse                  bool     `bson:"sparse"`
+	ExpireAfterSeconds      *int64   `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+	Collation               *struct {
+		Locale   string `bson:"locale"`
+		Strength int    `bson:"strength"`
+	} `bson:"collation"`
+}
+
+// EnsureIndexes creates missing indexes and reports existing indexes which differ from desired ones.
+// Indexes are matched by name, default name is built by mongo rules, e.g. "userID_1_createdAt_-1".
+// Missing indexes are created even if mismatches are found
+func (db *DB) EnsureIndexes(indexes []Index) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.EnsureIndexesCtx(ctx, indexes)
+}
+
+// EnsureIndexesCtx creates missing indexes with context and reports existing indexes which differ from
+// desired ones. Indexes are matched by name, default name is built by mongo rules, e.g. "userID_1_createdAt_-1".
+// Missing indexes are created even if mismatches are found
+func (db *DB) EnsureIndexesCtx(ctx context.Context, indexes []Index) error {
+	existing := map[string]map[string]indexSpec{}
+	var missing []Index
+	var mismatches []string
+	for _, index := range indexes {
+		specs, ok := existing[index.Collection]
+		if !ok {
+			var err error
+			if specs, err = db.indexSpecs(ctx, index.Collection); err != nil {
+				return err
+			}
+			existing[index.Collection] = specs
+		}
+
+		name := index.indexName()
+		spec, ok := specs[name]
+		if !ok {
+			if other, found := sameKeys(specs, index); found {
+				mismatches = append(mismatches, fmt.Sprintf("%s.%s: keys exist as index %s", index.Collection, name, other))
+				continue
+			}
+			missing = append(missing, index)
+			continue
+		}
+
+		for _, diff := range index.diff(spec) {
+			mismatches = append(mismatches, fmt.Sprintf("%s.%s: %s", index.Collection, name, diff))
+		}
+	}
+
+	if len(missing) > 0 {
+		if err := db.CreateIndicesCtx(ctx, missing); err != nil {
+			return err
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("index mismatch: %s", strings.Join(mismatches, "; "))
+	}
+	return nil
+}
+
+// indexSpecs of collection by name
+func (db *DB) indexSpecs(ctx context.Context, collection string) (map[string]indexSpec, error) {
+	cur, err := db.Collection(collection).Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var list []indexSpec
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	specs := make(map[string]indexSpec, len(list))
+	for _, s := range list {
+		specs[s.Name] = s
+	}
+	return specs, nil
+}
+
+// indexName is Name or default name mongo gives to index keys
+func (index Index) indexName() string {
+	if index.Name != "" {
+		return index.Name
+	}
+
+	parts := make([]string, 0, len(index.keys()))
+	for _, k := range index.keys() {
+		parts = append(parts, fmt.Sprintf("%s_%v", k.Key, k.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+// diff of index options with existing spec
+func (index Index) diff(spec indexSpec) []string {
+	var diffs []string
+	if index.typeCount(IndexText) == 0 && !keysEqual(index.keys(), spec.Key) {
+		diffs = append(diffs, fmt.Sprintf("keys %v, want %v", spec.Key, index.keys()))
+	}
+	if spec.Unique != index.Unique {
+		diffs = append(diffs, fmt.Sprintf("unique %v, want %v", spec.Unique, index.Unique))
+	}
+	if spec.Sparse != index.Sparse {
+		diffs = append(diffs, fmt.Sprintf("sparse %v, want %v", spec.Sparse, index.Sparse))
+	}
+
+	var expire int64
+	if spec.ExpireAfterSeconds != nil {
+		expire = *spec.ExpireAfterSeconds
+	}
+	if want := int64(index.ExpireAfter.Seconds()); expire != want {
+		diffs = append(diffs, fmt.Sprintf("expireAfterSeconds %d, want %d", expire, want))
+	}
+
+	if have, want := extJSON(spec.PartialFilterExpression), extJSON(index.PartialFilter); have != want {
+		diffs = append(diffs, fmt.Sprintf("partialFilterExpression %s, want %s", have, want))
+	}
+
+	if index.Collation != nil {
+		if spec.Collation == nil {
+			diffs = append(diffs, fmt.Sprintf("collation none, want %s/%d", index.Collation.Locale, index.Collation.Strength))
+		} else if spec.Collation.Locale != index.Collation.Locale ||
+			(index.Collation.Strength != 0 && spec.Collation.Strength != index.Collation.Strength) {
+			diffs = append(diffs, fmt.Sprintf("collation %s/%d, want %s/%d", spec.Collation.Locale, spec.Collation.Strength, index.Collation.Locale, index.Collation.Strength))
+		}
+	} else if spec.Collation != nil {
+		diffs = append(diffs, fmt.Sprintf("collation %s, want none", spec.Collation.Locale))
+	}
+
+	return diffs
+}
+
+// sameKeys finds name of existing index with keys of index
+func sameKeys(specs map[string]indexSpec, index Index) (string, bool) {
+	if index.typeCount(IndexText) > 0 {
+		return "", false
+	}
+	for name, s := range specs {
+		if keysEqual(index.keys(), s.Key) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// keysEqual compares index keys in order, numbers are compared by value
+func keysEqual(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || fmt.Sprint(keyValue(a[i].Value)) != fmt.Sprint(keyValue(b[i].Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyValue converts numeric index key value to float64 since mongo may store 1 as int32, int64 or double
+func keyValue(v interface{}) interface{} {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+	return v
+}
+
+// extJSON of document for comparison, empty string for empty document
+func extJSON(doc interface{}) string {
+	switch d := doc.(type) {
+	case bson.Raw:
+		if len(d) == 0 {
+			return ""
+		}
+	case bson.D:
+		if len(d) == 0 {
+			return ""
+		}
+	}
+	data, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return fmt.Sprint(doc)
+	}
+	return string(data)
+}
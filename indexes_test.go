@@ -0,0 +1,109 @@
+package mgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestIndexName(t *testing.T) {
+	tests := []struct {
+		name  string
+		index Index
+		want  string
+	}{
+		{name: "explicit", index: Index{Name: "by_user", Field: "userID"}, want: "by_user"},
+		{name: "single", index: Index{Field: "userID"}, want: "userID_1"},
+		{
+			name:  "compound",
+			index: Index{Fields: []IndexField{{Name: "userID"}, {Name: "createdAt", Order: -1}}},
+			want:  "userID_1_createdAt_-1",
+		},
+		{name: "text", index: Index{Field: "desc", Type: IndexText}, want: "desc_text"},
+		{name: "wildcard", index: Index{Field: WildcardField("")}, want: "$**_1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.index.indexName(); got != tt.want {
+				t.Fatalf("indexName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexDiff(t *testing.T) {
+	expire := int64(3600)
+	filter, err := bson.Marshal(bson.D{{Key: "deleted", Value: false}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		index Index
+		spec  indexSpec
+		want  []string
+	}{
+		{
+			name:  "equal with numbers of other type",
+			index: Index{Field: "a", Order: -1},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: int32(-1)}}},
+		},
+		{
+			name:  "keys",
+			index: Index{Field: "a"},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: -1.0}}},
+			want:  []string{"keys"},
+		},
+		{
+			name:  "unique and sparse",
+			index: Index{Field: "a", Unique: true},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: 1}}, Sparse: true},
+			want:  []string{"unique", "sparse"},
+		},
+		{
+			name:  "TTL",
+			index: Index{Field: "a", ExpireAfter: 2 * time.Hour},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: 1}}, ExpireAfterSeconds: &expire},
+			want:  []string{"expireAfterSeconds"},
+		},
+		{
+			name:  "partial filter equal",
+			index: Index{Field: "a", PartialFilter: bson.D{{Key: "deleted", Value: false}}},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: 1}}, PartialFilterExpression: filter},
+		},
+		{
+			name:  "partial filter missing",
+			index: Index{Field: "a"},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: 1}}, PartialFilterExpression: filter},
+			want:  []string{"partialFilterExpression"},
+		},
+		{
+			name:  "collation missing",
+			index: Index{Field: "a", Collation: &options.Collation{Locale: "en", Strength: 2}},
+			spec:  indexSpec{Key: bson.D{{Key: "a", Value: 1}}},
+			want:  []string{"collation"},
+		},
+		{
+			name:  "text keys are not compared",
+			index: Index{Field: "desc", Type: IndexText},
+			spec:  indexSpec{Key: bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.index.diff(tt.spec)
+			if len(got) != len(tt.want) {
+				t.Fatalf("diff() = %q, want %d differences %q", got, len(tt.want), tt.want)
+			}
+			for i, prefix := range tt.want {
+				if !strings.HasPrefix(got[i], prefix) {
+					t.Fatalf("diff()[%d] = %q, want prefix %q", i, got[i], prefix)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package mgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// SlowCommandMonitor logs commands running at least threshold and all failed commands with durations.
+// Zero threshold logs every command. Pass it to NewDatabaseWithOptions via options.Client().SetMonitor,
+// e.g. SlowCommandMonitor(100*time.Millisecond, log.Printf)
+func SlowCommandMonitor(threshold time.Duration, logf func(format string, args ...interface{})) *event.CommandMonitor {
+	var commands sync.Map
+
+	// raw command is copied since driver reuses its buffer, it is converted to JSON only when logged
+	command := func(requestID int64) string {
+		cmd, ok := commands.LoadAndDelete(requestID)
+		if !ok {
+			return ""
+		}
+		return cmd.(bson.Raw).String()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			commands.Store(e.RequestID, append(bson.Raw(nil), e.Command...))
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			if e.Duration < threshold {
+				commands.Delete(e.RequestID)
+				return
+			}
+			logf("mongo %s %s took %s: %s", e.DatabaseName, e.CommandName, e.Duration, command(e.RequestID))
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			logf("mongo %s %s failed after %s: %s: %s", e.DatabaseName, e.CommandName, e.Duration, e.Failure, command(e.RequestID))
+		},
+	}
+}
+
+// MetricsRecorder receives result of every command, e.g. to update prometheus
+// counters and histograms labeled by command, collection and success
+type MetricsRecorder interface {
+	RecordCommand(command, collection string, duration time.Duration, err error)
+}
+
+// MetricsMonitor reports every finished command to recorder. Pass it to NewDatabaseWithOptions
+// via options.Client().SetMonitor
+func MetricsMonitor(recorder MetricsRecorder) *event.CommandMonitor {
+	var collections sync.Map
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			collections.Store(e.RequestID, CommandCollection(e.Command))
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			collection, _ := collections.LoadAndDelete(e.RequestID)
+			name, _ := collection.(string)
+			recorder.RecordCommand(e.CommandName, name, e.Duration, nil)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			collection, _ := collections.LoadAndDelete(e.RequestID)
+			name, _ := collection.(string)
+			recorder.RecordCommand(e.CommandName, name, e.Duration, errors.New(e.Failure))
+		},
+	}
+}
+
+// ChainMonitors calls all monitors in order since client accepts only one
+func ChainMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
+}
+
+// CommandCollection is collection name of command for custom monitors, empty for database commands.
+// Collection of getMore is taken from its collection field since the first one is cursor id
+func CommandCollection(cmd bson.Raw) string {
+	elems, err := cmd.Elements()
+	if err != nil || len(elems) == 0 {
+		return ""
+	}
+	if elems[0].Key() == "getMore" {
+		name, _ := cmd.Lookup("collection").StringValueOK()
+		return name
+	}
+	name, _ := elems[0].Value().StringValueOK()
+	return name
+}
@@ -0,0 +1,31 @@
+package mgo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCommandCollection(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  bson.D
+		want string
+	}{
+		{name: "find", cmd: bson.D{{Key: "find", Value: "users"}, {Key: "filter", Value: bson.D{}}}, want: "users"},
+		{name: "getMore", cmd: bson.D{{Key: "getMore", Value: int64(42)}, {Key: "collection", Value: "users"}}, want: "users"},
+		{name: "database command", cmd: bson.D{{Key: "ping", Value: 1}}, want: ""},
+		{name: "empty", cmd: bson.D{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := bson.Marshal(tt.cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := CommandCollection(raw); got != tt.want {
+				t.Fatalf("CommandCollection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
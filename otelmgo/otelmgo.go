@@ -0,0 +1,72 @@
+// Package otelmgo traces mongo commands with OpenTelemetry.
+// It is a separate package so that programs importing only mgo do not compile and link otel
+package otelmgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/romanserikov/mgo"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/romanserikov/mgo/otelmgo"
+
+type spanKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// Monitor creates client span for every command as child of operation context, so spans of
+// mgo Ctx methods follow the caller trace. Pass it to mgo.NewDatabaseWithOptions via
+// options.Client().SetMonitor. Nil provider means global otel provider
+func Monitor(provider trace.TracerProvider) *event.CommandMonitor {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	tracer := provider.Tracer(tracerName)
+
+	var spans sync.Map
+
+	end := func(e event.CommandFinishedEvent, failure string) {
+		v, ok := spans.LoadAndDelete(spanKey{e.ConnectionID, e.RequestID})
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		if failure != "" {
+			span.RecordError(errors.New(failure))
+			span.SetStatus(codes.Error, failure)
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system", "mongodb"),
+				attribute.String("db.name", e.DatabaseName),
+				attribute.String("db.operation", e.CommandName),
+			}
+			name := e.CommandName
+			if collection := mgo.CommandCollection(e.Command); collection != "" {
+				attrs = append(attrs, attribute.String("db.mongodb.collection", collection))
+				name += " " + collection
+			}
+
+			_, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+			spans.Store(spanKey{e.ConnectionID, e.RequestID}, span)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			end(e.CommandFinishedEvent, "")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			end(e.CommandFinishedEvent, e.Failure)
+		},
+	}
+}
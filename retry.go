@@ -0,0 +1,56 @@
+package mgo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RetryPolicy for transient errors. Backoff is doubled after every failed attempt
+type RetryPolicy struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// SetReadRetry enables retries of GetItem, GetItems, CountDocuments, EstimatedCount and Distinct
+// with their Ctx variants on transient errors. Zero policy disables retries
+func (db *DB) SetReadRetry(policy RetryPolicy) {
+	db.readRetry = policy
+}
+
+// retryRead runs fn with read retry policy of DB
+func (db *DB) retryRead(ctx context.Context, fn func(ctx context.Context) error) error {
+	return Retry(ctx, db.readRetry, fn)
+}
+
+// Retry fn up to policy attempts while it fails with transient error, see IsTransient.
+// Waiting between attempts is interrupted when ctx is done
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	backoff := policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= policy.Attempts || !IsTransient(err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// IsTransient reports whether err is network or server timeout error which may succeed on retry.
+// Context cancellation, duplicate key, validation and other server errors are not transient
+func IsTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return mongo.IsNetworkError(err) || mongo.IsTimeout(err)
+}
@@ -0,0 +1,44 @@
+package mgo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DeletedAtField is set on soft deleted documents
+const DeletedAtField = "deletedAt"
+
+// NotDeleted extends filter to exclude soft deleted documents. Use it with read methods
+func NotDeleted(filter bson.D) bson.D {
+	return append(filter[:len(filter):len(filter)], bson.E{Key: DeletedAtField, Value: bson.D{{Key: "$exists", Value: false}}})
+}
+
+// SoftDelete marks documents in collection as deleted by setting deletedAt to current time
+func (db *DB) SoftDelete(collection string, filter bson.D) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.SoftDeleteCtx(ctx, collection, filter)
+}
+
+// SoftDeleteCtx marks documents in collection as deleted with context by setting deletedAt to current time
+func (db *DB) SoftDeleteCtx(ctx context.Context, collection string, filter bson.D) error {
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: DeletedAtField, Value: time.Now().UTC()}}}}
+	_, err := db.UpdateItemsCtx(ctx, collection, NotDeleted(filter), update)
+	return err
+}
+
+// Restore soft deleted documents in collection by removing deletedAt
+func (db *DB) Restore(collection string, filter bson.D) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.RestoreCtx(ctx, collection, filter)
+}
+
+// RestoreCtx soft deleted documents in collection with context by removing deletedAt
+func (db *DB) RestoreCtx(ctx context.Context, collection string, filter bson.D) error {
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: DeletedAtField, Value: ""}}}}
+	_, err := db.UpdateItemsCtx(ctx, collection, filter, update)
+	return err
+}
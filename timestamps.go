@@ -0,0 +1,164 @@
+package mgo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default timestamp field names
+const (
+	DefaultCreatedAtField = "createdAt"
+	DefaultUpdatedAtField = "updatedAt"
+)
+
+// SetTimestampFields sets field names used by WithTimestamps methods. Empty name keeps default
+func (db *DB) SetTimestampFields(createdAt, updatedAt string) {
+	db.createdAtField = createdAt
+	db.updatedAtField = updatedAt
+}
+
+func (db *DB) createdAt() string {
+	if db.createdAtField == "" {
+		return DefaultCreatedAtField
+	}
+	return db.createdAtField
+}
+
+func (db *DB) updatedAt() string {
+	if db.updatedAtField == "" {
+		return DefaultUpdatedAtField
+	}
+	return db.updatedAtField
+}
+
+// InsertItemWithTimestamps in collection setting createdAt and updatedAt unless item has them
+func (db *DB) InsertItemWithTimestamps(collection string, item interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.InsertItemWithTimestampsCtx(ctx, collection, item)
+}
+
+// InsertItemWithTimestampsCtx in collection with context setting createdAt and updatedAt unless item has them
+func (db *DB) InsertItemWithTimestampsCtx(ctx context.Context, collection string, item interface{}) error {
+	doc, err := toDoc(item)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	doc = stamp(doc, db.createdAt(), now)
+	doc = stamp(doc, db.updatedAt(), now)
+	return db.InsertItemCtx(ctx, collection, doc)
+}
+
+// UpdateItemWithTimestamps in collection adding updatedAt to $set of update unless it is there
+func (db *DB) UpdateItemWithTimestamps(collection string, filter bson.D, update bson.D) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.UpdateItemWithTimestampsCtx(ctx, collection, filter, update)
+}
+
+// UpdateItemWithTimestampsCtx in collection with context adding updatedAt to $set of update unless it is there
+func (db *DB) UpdateItemWithTimestampsCtx(ctx context.Context, collection string, filter bson.D, update bson.D) error {
+	stamped, err := db.stampUpdate(update, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	return db.UpdateItemCtx(ctx, collection, filter, stamped)
+}
+
+// UpsertItemWithTimestamps in collection. Item fields are set on matched document with updatedAt,
+// createdAt is set only when document is inserted. Unlike UpsertItem fields missing in item are kept
+func (db *DB) UpsertItemWithTimestamps(collection string, filter bson.D, item interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.UpsertItemWithTimestampsCtx(ctx, collection, filter, item)
+}
+
+// UpsertItemWithTimestampsCtx in collection with context. Item fields are set on matched document with updatedAt,
+// createdAt is set only when document is inserted. Unlike UpsertItem fields missing in item are kept
+func (db *DB) UpsertItemWithTimestampsCtx(ctx context.Context, collection string, filter bson.D, item interface{}) error {
+	doc, err := toDoc(item)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	set := make(bson.D, 0, len(doc)+1)
+	createdAt := bson.E{Key: db.createdAt(), Value: now}
+	for _, e := range doc {
+		if e.Key == "_id" {
+			continue
+		}
+		if e.Key == createdAt.Key {
+			if !isZeroTime(e.Value) {
+				createdAt.Value = e.Value
+			}
+			continue
+		}
+		set = append(set, e)
+	}
+	set = stamp(set, db.updatedAt(), now)
+
+	update := bson.D{
+		{Key: "$set", Value: set},
+		{Key: "$setOnInsert", Value: bson.D{createdAt}},
+	}
+	_, err = db.Collection(collection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// stampUpdate adds updatedAt to $set of update unless it is set already
+func (db *DB) stampUpdate(update bson.D, now time.Time) (bson.D, error) {
+	field := db.updatedAt()
+	return mergeOperator(update, "$set", func(set bson.D) bson.D {
+		return stamp(set, field, now)
+	})
+}
+
+// toDoc converts item into ordered document
+func toDoc(item interface{}) (bson.D, error) {
+	if doc, ok := item.(bson.D); ok {
+		return append(bson.D(nil), doc...), nil
+	}
+
+	data, err := bson.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// stamp sets field to now unless doc has non-zero value of it
+func stamp(doc bson.D, field string, now time.Time) bson.D {
+	for i, e := range doc {
+		if e.Key == field {
+			if isZeroTime(e.Value) {
+				doc[i].Value = now
+			}
+			return doc
+		}
+	}
+	return append(doc, bson.E{Key: field, Value: now})
+}
+
+// isZeroTime reports whether v is empty or zero time value
+func isZeroTime(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case time.Time:
+		return t.IsZero()
+	case primitive.DateTime:
+		return t == primitive.NewDateTimeFromTime(time.Time{})
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package mgo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStampUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	tests := []struct {
+		name    string
+		update  bson.D
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name:   "no $set",
+			update: bson.D{{Key: "$inc", Value: bson.D{{Key: "n", Value: 1}}}},
+			want: bson.D{
+				{Key: "$inc", Value: bson.D{{Key: "n", Value: 1}}},
+				{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: now}}},
+			},
+		},
+		{
+			name:   "bson.D $set",
+			update: bson.D{{Key: "$set", Value: bson.D{{Key: "a", Value: 1}}}},
+			want:   bson.D{{Key: "$set", Value: bson.D{{Key: "a", Value: 1}, {Key: "updatedAt", Value: now}}}},
+		},
+		{
+			name:   "bson.M $set",
+			update: bson.D{{Key: "$set", Value: bson.M{"b": 2, "a": 1}}},
+			want:   bson.D{{Key: "$set", Value: bson.D{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "updatedAt", Value: now}}}},
+		},
+		{
+			name:   "map $set",
+			update: bson.D{{Key: "$set", Value: map[string]interface{}{"a": 1}}},
+			want:   bson.D{{Key: "$set", Value: bson.D{{Key: "a", Value: 1}, {Key: "updatedAt", Value: now}}}},
+		},
+		{
+			name:   "updatedAt kept",
+			update: bson.D{{Key: "$set", Value: bson.M{"updatedAt": earlier}}},
+			want:   bson.D{{Key: "$set", Value: bson.D{{Key: "updatedAt", Value: earlier}}}},
+		},
+		{name: "unsupported $set", update: bson.D{{Key: "$set", Value: []int{1}}}, wantErr: true},
+	}
+	db := &DB{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := db.stampUpdate(tt.update, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("stampUpdate() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("stampUpdate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("stampUpdate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package mgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DefaultVersionField is document version field name used by UpdateWithVersion
+const DefaultVersionField = "version"
+
+// SetVersionField sets field name used by UpdateWithVersion. Empty name keeps default
+func (db *DB) SetVersionField(name string) {
+	db.versionField = name
+}
+
+func (db *DB) version() string {
+	if db.versionField == "" {
+		return DefaultVersionField
+	}
+	return db.versionField
+}
+
+// UpdateWithVersion in collection if document version equals expectedVersion and increment version.
+// Returns ErrVersionConflict if document with the id and version is not found
+func (db *DB) UpdateWithVersion(collection string, id interface{}, expectedVersion int64, update bson.D) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.UpdateWithVersionCtx(ctx, collection, id, expectedVersion, update)
+}
+
+// UpdateWithVersionCtx in collection with context if document version equals expectedVersion and increment version.
+// Returns ErrVersionConflict if document with the id and version is not found
+func (db *DB) UpdateWithVersionCtx(ctx context.Context, collection string, id interface{}, expectedVersion int64, update bson.D) error {
+	field := db.version()
+	filter := bson.D{{Key: "_id", Value: id}, {Key: field, Value: expectedVersion}}
+
+	versioned, err := versionUpdate(update, field)
+	if err != nil {
+		return err
+	}
+
+	res, err := db.Collection(collection).UpdateOne(ctx, filter, versioned)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// versionUpdate adds increment of version field to $inc of update
+func versionUpdate(update bson.D, field string) (bson.D, error) {
+	return mergeOperator(update, "$inc", func(inc bson.D) bson.D {
+		return append(inc, bson.E{Key: field, Value: int64(1)})
+	})
+}
+
+// IncrementField of document in collection by delta, negative delta decrements.
+// Returns ErrNotFound if nothing matched
+func (db *DB) IncrementField(collection string, filter bson.D, field string, delta int64) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.IncrementFieldCtx(ctx, collection, filter, field, delta)
+}
+
+// IncrementFieldCtx of document in collection with context by delta, negative delta decrements.
+// Returns ErrNotFound if nothing matched
+func (db *DB) IncrementFieldCtx(ctx context.Context, collection string, filter bson.D, field string, delta int64) error {
+	return db.updateOneCtx(ctx, collection, filter, bson.D{{Key: "$inc", Value: bson.D{{Key: field, Value: delta}}}})
+}
+
+// PushToArray appends value to array field of document. Returns ErrNotFound if nothing matched
+func (db *DB) PushToArray(collection string, filter bson.D, field string, value interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.PushToArrayCtx(ctx, collection, filter, field, value)
+}
+
+// PushToArrayCtx appends value to array field of document with context. Returns ErrNotFound if nothing matched
+func (db *DB) PushToArrayCtx(ctx context.Context, collection string, filter bson.D, field string, value interface{}) error {
+	return db.updateOneCtx(ctx, collection, filter, bson.D{{Key: "$push", Value: bson.D{{Key: field, Value: value}}}})
+}
+
+// AddToSet appends value to array field of document unless it is there already.
+// Returns ErrNotFound if nothing matched
+func (db *DB) AddToSet(collection string, filter bson.D, field string, value interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.AddToSetCtx(ctx, collection, filter, field, value)
+}
+
+// AddToSetCtx appends value to array field of document with context unless it is there already.
+// Returns ErrNotFound if nothing matched
+func (db *DB) AddToSetCtx(ctx context.Context, collection string, filter bson.D, field string, value interface{}) error {
+	return db.updateOneCtx(ctx, collection, filter, bson.D{{Key: "$addToSet", Value: bson.D{{Key: field, Value: value}}}})
+}
+
+// PullFromArray removes all occurrences of value from array field of document.
+// Returns ErrNotFound if nothing matched
+func (db *DB) PullFromArray(collection string, filter bson.D, field string, value interface{}) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.PullFromArrayCtx(ctx, collection, filter, field, value)
+}
+
+// PullFromArrayCtx removes all occurrences of value from array field of document with context.
+// Returns ErrNotFound if nothing matched
+func (db *DB) PullFromArrayCtx(ctx context.Context, collection string, filter bson.D, field string, value interface{}) error {
+	return db.updateOneCtx(ctx, collection, filter, bson.D{{Key: "$pull", Value: bson.D{{Key: field, Value: value}}}})
+}
+
+// SetFields of document without replacing other fields. Returns ErrNotFound if nothing matched
+func (db *DB) SetFields(collection string, filter bson.D, fields bson.M) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.SetFieldsCtx(ctx, collection, filter, fields)
+}
+
+// SetFieldsCtx of document with context without replacing other fields. Returns ErrNotFound if nothing matched
+func (db *DB) SetFieldsCtx(ctx context.Context, collection string, filter bson.D, fields bson.M) error {
+	return db.updateOneCtx(ctx, collection, filter, bson.D{{Key: "$set", Value: fields}})
+}
+
+// UnsetFields removes fields from document. Returns ErrNotFound if nothing matched
+func (db *DB) UnsetFields(collection string, filter bson.D, fields ...string) error {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.UnsetFieldsCtx(ctx, collection, filter, fields...)
+}
+
+// UnsetFieldsCtx removes fields from document with context. Returns ErrNotFound if nothing matched
+func (db *DB) UnsetFieldsCtx(ctx context.Context, collection string, filter bson.D, fields ...string) error {
+	unset := make(bson.D, 0, len(fields))
+	for _, f := range fields {
+		unset = append(unset, bson.E{Key: f, Value: ""})
+	}
+	return db.updateOneCtx(ctx, collection, filter, bson.D{{Key: "$unset", Value: unset}})
+}
+
+// updateOneCtx runs update on one document and returns ErrNotFound if nothing matched
+func (db *DB) updateOneCtx(ctx context.Context, collection string, filter bson.D, update interface{}) error {
+	res, err := db.Collection(collection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// mergeOperator passes copy of operator document of update, e.g. $set, to merge and puts result back.
+// Operator is appended when update does not have it, so update never gets duplicate operator keys
+func mergeOperator(update bson.D, op string, merge func(doc bson.D) bson.D) (bson.D, error) {
+	merged := make(bson.D, 0, len(update)+1)
+	found := false
+	for _, e := range update {
+		if e.Key == op {
+			doc, err := operatorDoc(op, e.Value)
+			if err != nil {
+				return nil, err
+			}
+			e.Value = merge(doc)
+			found = true
+		}
+		merged = append(merged, e)
+	}
+	if !found {
+		merged = append(merged, bson.E{Key: op, Value: merge(nil)})
+	}
+	return merged, nil
+}
+
+// operatorDoc copies bson.D or map value of update operator, map keys are sorted
+func operatorDoc(op string, value interface{}) (bson.D, error) {
+	var m map[string]interface{}
+	switch v := value.(type) {
+	case bson.D:
+		return append(bson.D(nil), v...), nil
+	case bson.M:
+		m = v
+	case map[string]interface{}:
+		m = v
+	default:
+		return nil, fmt.Errorf("unsupported %s value type %T, use bson.D or bson.M", op, value)
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	doc := make(bson.D, 0, len(m)+1)
+	for _, k := range keys {
+		doc = append(doc, bson.E{Key: k, Value: m[k]})
+	}
+	return doc, nil
+}
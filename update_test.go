@@ -0,0 +1,54 @@
+package mgo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestVersionUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  bson.D
+		want    bson.D
+		wantErr bool
+	}{
+		{
+			name:   "no $inc",
+			update: bson.D{{Key: "$set", Value: bson.M{"a": 1}}},
+			want: bson.D{
+				{Key: "$set", Value: bson.M{"a": 1}},
+				{Key: "$inc", Value: bson.D{{Key: "version", Value: int64(1)}}},
+			},
+		},
+		{
+			name:   "bson.D $inc",
+			update: bson.D{{Key: "$inc", Value: bson.D{{Key: "n", Value: 2}}}},
+			want:   bson.D{{Key: "$inc", Value: bson.D{{Key: "n", Value: 2}, {Key: "version", Value: int64(1)}}}},
+		},
+		{
+			name:   "bson.M $inc",
+			update: bson.D{{Key: "$inc", Value: bson.M{"n": 2}}},
+			want:   bson.D{{Key: "$inc", Value: bson.D{{Key: "n", Value: 2}, {Key: "version", Value: int64(1)}}}},
+		},
+		{name: "unsupported $inc", update: bson.D{{Key: "$inc", Value: "n"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := versionUpdate(tt.update, "version")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("versionUpdate() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("versionUpdate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("versionUpdate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
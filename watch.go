@@ -0,0 +1,94 @@
+package mgo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Watch changes of collection. Change streams require replica set or sharded cluster.
+// Nil pipeline watches all changes. Caller must close returned stream
+func (db *DB) Watch(collection string, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.WatchCtx(ctx, collection, pipeline, opts...)
+}
+
+// WatchCtx changes of collection with context. Change streams require replica set or sharded cluster.
+// Nil pipeline watches all changes. Caller must close returned stream
+func (db *DB) WatchCtx(ctx context.Context, collection string, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+	return db.Collection(collection).Watch(ctx, pipeline, opts...)
+}
+
+// WatchDatabase changes of all collections in database. Change streams require replica set
+// or sharded cluster. Nil pipeline watches all changes. Caller must close returned stream
+func (db *DB) WatchDatabase(pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	ctx, cancel := db.opContext()
+	defer cancel()
+	return db.WatchDatabaseCtx(ctx, pipeline, opts...)
+}
+
+// WatchDatabaseCtx changes of all collections in database with context. Change streams require replica set
+// or sharded cluster. Nil pipeline watches all changes. Caller must close returned stream
+func (db *DB) WatchDatabaseCtx(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+	return db.Database(db.name).Watch(ctx, pipeline, opts...)
+}
+
+// ResumeOptions for change stream continuing after saved resume token or,
+// when token is empty, starting at operation time. Both empty give default options
+func ResumeOptions(resumeToken bson.Raw, startAt *primitive.Timestamp) *options.ChangeStreamOptions {
+	opts := options.ChangeStream()
+	if len(resumeToken) > 0 {
+		return opts.SetResumeAfter(resumeToken)
+	}
+	if startAt != nil {
+		opts.SetStartAtOperationTime(startAt)
+	}
+	return opts
+}
+
+// WatchEach change of collection after resumeToken, empty token starts from now. fn gets every change
+// with resume token of it, persist the token after change is processed to continue from it after restart.
+// Watching stops on first error returned by fn or when ctx is done
+func (db *DB) WatchEach(ctx context.Context, collection string, pipeline interface{}, resumeToken bson.Raw, fn func(change, resumeToken bson.Raw) error) error {
+	cs, err := db.WatchCtx(ctx, collection, pipeline, ResumeOptions(resumeToken, nil))
+	if err != nil {
+		return err
+	}
+	return consumeChanges(ctx, cs, fn)
+}
+
+// WatchDatabaseEach change of all collections in database after resumeToken, see WatchEach
+func (db *DB) WatchDatabaseEach(ctx context.Context, pipeline interface{}, resumeToken bson.Raw, fn func(change, resumeToken bson.Raw) error) error {
+	cs, err := db.WatchDatabaseCtx(ctx, pipeline, ResumeOptions(resumeToken, nil))
+	if err != nil {
+		return err
+	}
+	return consumeChanges(ctx, cs, fn)
+}
+
+// consumeChanges calls fn for every change of stream and closes it
+func consumeChanges(ctx context.Context, cs *mongo.ChangeStream, fn func(change, resumeToken bson.Raw) error) error {
+	// ctx is usually done on exit, killCursors needs own bounded context
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), closeGrace)
+		defer cancel()
+		_ = cs.Close(closeCtx)
+	}()
+
+	for cs.Next(ctx) {
+		if err := fn(cs.Current, cs.ResumeToken()); err != nil {
+			return err
+		}
+	}
+	return cs.Err()
+}